@@ -0,0 +1,8 @@
+// Package webhook contains an http.Handler that receives GitHub webhook
+// deliveries, validates their X-Hub-Signature-256 header, and dispatches the
+// decoded issue, pull request, push, and comment events to registered handler
+// funcs.
+//
+// Programs that run their own HTTP server can use ValidatePayload,
+// ValidateSignature, and the Parse*Event funcs directly instead of Handler.
+package webhook
@@ -0,0 +1,235 @@
+package webhook
+
+import (
+	"strings"
+	"time"
+)
+
+// Event names as sent by GitHub in the X-GitHub-Event header.
+const (
+	EventPing         = "ping"
+	EventIssues       = "issues"
+	EventIssueComment = "issue_comment"
+	EventPullRequest  = "pull_request"
+	EventPush         = "push"
+)
+
+// User is a GitHub account referenced by an event.
+type User struct {
+	ID      int64  `json:"id"`
+	Login   string `json:"login"`
+	Type    string `json:"type"`
+	HTMLURL string `json:"html_url"`
+}
+
+// Repository is the repository an event was delivered for.
+type Repository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Owner         User   `json:"owner"`
+	Private       bool   `json:"private"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// Installation identifies the GitHub App installation that received an event.
+type Installation struct {
+	ID int64 `json:"id"`
+}
+
+// Label is an issue or pull request label.
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Issue is an issue, or the issue half of a pull request.
+type Issue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	User        User      `json:"user"`
+	Labels      []Label   `json:"labels"`
+	HTMLURL     string    `json:"html_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request,omitempty"`
+}
+
+// Comment is a comment on an issue or pull request.
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      User      `json:"user"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PullRequestBranch is the head or base of a pull request.
+type PullRequestBranch struct {
+	Ref  string      `json:"ref"`
+	SHA  string      `json:"sha"`
+	Repo *Repository `json:"repo"`
+}
+
+// PullRequest is a pull request referenced by an event.
+type PullRequest struct {
+	Number  int               `json:"number"`
+	Title   string            `json:"title"`
+	Body    string            `json:"body"`
+	State   string            `json:"state"`
+	Draft   bool              `json:"draft"`
+	Merged  bool              `json:"merged"`
+	User    User              `json:"user"`
+	Labels  []Label           `json:"labels"`
+	Head    PullRequestBranch `json:"head"`
+	Base    PullRequestBranch `json:"base"`
+	HTMLURL string            `json:"html_url"`
+}
+
+// CommitAuthor is the author or committer of a pushed commit.
+type CommitAuthor struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// Commit is a commit included in a push.
+type Commit struct {
+	ID        string       `json:"id"`
+	Message   string       `json:"message"`
+	Timestamp time.Time    `json:"timestamp"`
+	URL       string       `json:"url"`
+	Author    CommitAuthor `json:"author"`
+	Added     []string     `json:"added"`
+	Removed   []string     `json:"removed"`
+	Modified  []string     `json:"modified"`
+}
+
+// IssuesEvent is delivered when an issue is opened, edited, labeled, closed,
+// and so on.
+type IssuesEvent struct {
+	Action       string        `json:"action"`
+	Issue        Issue         `json:"issue"`
+	Label        *Label        `json:"label,omitempty"`
+	Repository   Repository    `json:"repository"`
+	Sender       User          `json:"sender"`
+	Installation *Installation `json:"installation,omitempty"`
+}
+
+// IssueCommentEvent is delivered when a comment on an issue or pull request
+// is created, edited, or deleted.
+type IssueCommentEvent struct {
+	Action       string        `json:"action"`
+	Issue        Issue         `json:"issue"`
+	Comment      Comment       `json:"comment"`
+	Repository   Repository    `json:"repository"`
+	Sender       User          `json:"sender"`
+	Installation *Installation `json:"installation,omitempty"`
+}
+
+// PullRequestEvent is delivered when a pull request is opened, synchronized,
+// closed, and so on.
+type PullRequestEvent struct {
+	Action       string        `json:"action"`
+	Number       int           `json:"number"`
+	PullRequest  PullRequest   `json:"pull_request"`
+	Repository   Repository    `json:"repository"`
+	Sender       User          `json:"sender"`
+	Installation *Installation `json:"installation,omitempty"`
+}
+
+// PushEvent is delivered when commits are pushed to a branch or tag.
+type PushEvent struct {
+	Ref        string   `json:"ref"`
+	Before     string   `json:"before"`
+	After      string   `json:"after"`
+	Created    bool     `json:"created"`
+	Deleted    bool     `json:"deleted"`
+	Forced     bool     `json:"forced"`
+	Commits    []Commit `json:"commits"`
+	HeadCommit *Commit  `json:"head_commit"`
+	Pusher     struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"pusher"`
+	Repository   Repository    `json:"repository"`
+	Sender       User          `json:"sender"`
+	Installation *Installation `json:"installation,omitempty"`
+}
+
+// IsPullRequest reports whether the issue is the issue half of a pull request,
+// as is the case for comments on pull requests.
+func (i Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// HasLabel reports whether the issue carries the named label.
+func (i Issue) HasLabel(name string) bool {
+	return hasLabel(i.Labels, name)
+}
+
+// HasLabel reports whether the pull request carries the named label.
+func (pr PullRequest) HasLabel(name string) bool {
+	return hasLabel(pr.Labels, name)
+}
+
+// Mentions reports whether the comment body @-mentions login.
+func (c Comment) Mentions(login string) bool {
+	mention := "@" + strings.ToLower(login)
+	body := strings.ToLower(c.Body)
+	for {
+		i := strings.Index(body, mention)
+		if i < 0 {
+			return false
+		}
+		if i > 0 && isLoginChar(body[i-1]) {
+			body = body[i+len(mention):]
+			continue
+		}
+		rest := body[i+len(mention):]
+		if rest == "" || !isLoginChar(rest[0]) {
+			return true
+		}
+		body = rest
+	}
+}
+
+// IsMerged reports whether the event closed the pull request by merging it.
+func (e *PullRequestEvent) IsMerged() bool {
+	return e.Action == "closed" && e.PullRequest.Merged
+}
+
+// Branch returns the name of the pushed branch, or "" if a tag was pushed.
+func (e *PushEvent) Branch() string {
+	branch, _ := strings.CutPrefix(e.Ref, "refs/heads/")
+	if branch == e.Ref {
+		return ""
+	}
+	return branch
+}
+
+// Tag returns the name of the pushed tag, or "" if a branch was pushed.
+func (e *PushEvent) Tag() string {
+	tag, _ := strings.CutPrefix(e.Ref, "refs/tags/")
+	if tag == e.Ref {
+		return ""
+	}
+	return tag
+}
+
+func hasLabel(labels []Label, name string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLoginChar(c byte) bool {
+	return c == '-' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
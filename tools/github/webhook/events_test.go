@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvent(t *testing.T) {
+	t.Parallel()
+
+	event, err := ParseEvent(EventPullRequest, []byte(`{
+		"action": "closed",
+		"number": 3,
+		"pull_request": {"number": 3, "merged": true, "labels": [{"name": "Bug"}], "base": {"ref": "main"}}
+	}`))
+	require.NoError(t, err)
+	pr, ok := event.(*PullRequestEvent)
+	require.True(t, ok)
+	require.True(t, pr.IsMerged())
+	require.True(t, pr.PullRequest.HasLabel("bug"))
+	require.False(t, pr.PullRequest.HasLabel("docs"))
+	require.Equal(t, "main", pr.PullRequest.Base.Ref)
+
+	_, err = ParseEvent("deployment", []byte(`{}`))
+	require.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	_, err = ParseIssuesEvent([]byte(`{"issue": []}`))
+	require.Error(t, err)
+}
+
+func TestCommentMentions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		body string
+		want bool
+	}{
+		{"@bot please take a look", true},
+		{"thanks, @Bot.", true},
+		{"cc @bot-reviewer", false},
+		{"mail me at me@bot.com", false},
+		{"@robot help", false},
+		{"no mention here", false},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, Comment{Body: tc.body}.Mentions("bot"), tc.body)
+	}
+}
+
+func TestPushEventRef(t *testing.T) {
+	t.Parallel()
+
+	branch := PushEvent{Ref: "refs/heads/release/1.x"}
+	require.Equal(t, "release/1.x", branch.Branch())
+	require.Empty(t, branch.Tag())
+
+	tag := PushEvent{Ref: "refs/tags/v1.2.0"}
+	require.Equal(t, "v1.2.0", tag.Tag())
+	require.Empty(t, tag.Branch())
+}
@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// _maxPayloadSize is the largest payload GitHub will deliver (25 MB).
+const _maxPayloadSize = 25 << 20
+
+var (
+	// ErrMissingSecret is returned by New when no webhook secret is given.
+	ErrMissingSecret = errors.New("webhook: missing secret")
+	// ErrInvalidSignature is returned when a delivery's X-Hub-Signature-256
+	// header is missing or does not match the payload.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	// ErrUnsupportedEvent is returned by ParseEvent for event types this
+	// package has no struct for.
+	ErrUnsupportedEvent = errors.New("webhook: unsupported event type")
+
+	errMalformedPayload = errors.New("webhook: malformed payload")
+)
+
+// Handler is an http.Handler that receives GitHub webhook deliveries and
+// dispatches them to the funcs registered with the On* methods. Deliveries
+// for events without a registered func are acknowledged and dropped.
+type Handler struct {
+	secret string
+
+	mu            sync.RWMutex
+	issues        []func(context.Context, *IssuesEvent) error
+	issueComments []func(context.Context, *IssueCommentEvent) error
+	pullRequests  []func(context.Context, *PullRequestEvent) error
+	pushes        []func(context.Context, *PushEvent) error
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// New creates a Handler that validates deliveries with the given webhook
+// secret.
+func New(secret string) (*Handler, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	return &Handler{secret: secret}, nil
+}
+
+// OnIssues registers fn to be called for every issues event.
+func (h *Handler) OnIssues(fn func(ctx context.Context, event *IssuesEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.issues = append(h.issues, fn)
+}
+
+// OnIssueComment registers fn to be called for every issue_comment event.
+// Comments on pull requests are delivered here as well.
+func (h *Handler) OnIssueComment(fn func(ctx context.Context, event *IssueCommentEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.issueComments = append(h.issueComments, fn)
+}
+
+// OnPullRequest registers fn to be called for every pull_request event.
+func (h *Handler) OnPullRequest(fn func(ctx context.Context, event *PullRequestEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pullRequests = append(h.pullRequests, fn)
+}
+
+// OnPush registers fn to be called for every push event.
+func (h *Handler) OnPush(fn func(ctx context.Context, event *PushEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pushes = append(h.pushes, fn)
+}
+
+// ServeHTTP validates the delivery signature, decodes the payload, and calls
+// the registered funcs in order. It responds 401 for a bad signature, 400 for
+// a malformed payload, and 500 if a registered func returns an error.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := ValidatePayload(r, h.secret)
+	switch {
+	case errors.Is(err, ErrInvalidSignature):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, "reading payload", http.StatusBadRequest)
+		return
+	}
+
+	err = h.dispatch(r.Context(), EventType(r), payload)
+	switch {
+	case errors.Is(err, errMalformedPayload):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *Handler) dispatch(ctx context.Context, eventType string, payload []byte) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch eventType {
+	case EventIssues:
+		return run(ctx, payload, h.issues)
+	case EventIssueComment:
+		return run(ctx, payload, h.issueComments)
+	case EventPullRequest:
+		return run(ctx, payload, h.pullRequests)
+	case EventPush:
+		return run(ctx, payload, h.pushes)
+	default:
+		return nil
+	}
+}
+
+func run[T any](ctx context.Context, payload []byte, fns []func(context.Context, *T) error) error {
+	if len(fns) == 0 {
+		return nil
+	}
+	event, err := decode[T](payload)
+	if err != nil {
+		return err
+	}
+	for _, fn := range fns {
+		if err := fn(ctx, event); err != nil {
+			return fmt.Errorf("webhook handler: %w", err)
+		}
+	}
+	return nil
+}
+
+func decode[T any](payload []byte) (*T, error) {
+	event := new(T)
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("%w: %w", errMalformedPayload, err)
+	}
+	return event, nil
+}
+
+// EventType returns the event name of a delivery, e.g. "issues" or "push".
+func EventType(r *http.Request) string {
+	return r.Header.Get("X-GitHub-Event")
+}
+
+// DeliveryID returns the unique ID GitHub assigned to a delivery.
+func DeliveryID(r *http.Request) string {
+	return r.Header.Get("X-GitHub-Delivery")
+}
+
+// ValidatePayload reads the body of a delivery and checks it against the
+// request's X-Hub-Signature-256 header. It returns the payload if the
+// signature is valid and ErrInvalidSignature if it is not.
+func ValidatePayload(r *http.Request, secret string) ([]byte, error) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, _maxPayloadSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading webhook payload: %w", err)
+	}
+	if err := ValidateSignature(payload, r.Header.Get("X-Hub-Signature-256"), secret); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// ValidateSignature checks that signature, the value of an
+// X-Hub-Signature-256 header, is the HMAC-SHA256 of payload keyed with secret.
+func ValidateSignature(payload []byte, signature, secret string) error {
+	hexSum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseEvent decodes payload into the struct for eventType. The result is one
+// of *IssuesEvent, *IssueCommentEvent, *PullRequestEvent, or *PushEvent.
+func ParseEvent(eventType string, payload []byte) (any, error) {
+	switch eventType {
+	case EventIssues:
+		return ParseIssuesEvent(payload)
+	case EventIssueComment:
+		return ParseIssueCommentEvent(payload)
+	case EventPullRequest:
+		return ParsePullRequestEvent(payload)
+	case EventPush:
+		return ParsePushEvent(payload)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedEvent, eventType)
+	}
+}
+
+// ParseIssuesEvent decodes an issues event payload.
+func ParseIssuesEvent(payload []byte) (*IssuesEvent, error) {
+	return decode[IssuesEvent](payload)
+}
+
+// ParseIssueCommentEvent decodes an issue_comment event payload.
+func ParseIssueCommentEvent(payload []byte) (*IssueCommentEvent, error) {
+	return decode[IssueCommentEvent](payload)
+}
+
+// ParsePullRequestEvent decodes a pull_request event payload.
+func ParsePullRequestEvent(payload []byte) (*PullRequestEvent, error) {
+	return decode[PullRequestEvent](payload)
+}
+
+// ParsePushEvent decodes a push event payload.
+func ParsePushEvent(payload []byte) (*PushEvent, error) {
+	return decode[PushEvent](payload)
+}
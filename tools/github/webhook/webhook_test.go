@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const _secret = "It's a Secret to Everybody"
+
+func sign(t *testing.T, payload string) string {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(_secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(t *testing.T, h http.Handler, event, payload, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewRequiresSecret(t *testing.T) {
+	t.Parallel()
+
+	_, err := New("")
+	require.ErrorIs(t, err, ErrMissingSecret)
+}
+
+func TestValidateSignature(t *testing.T) {
+	t.Parallel()
+
+	// Example delivery from the GitHub webhook documentation.
+	payload := []byte("Hello, World!")
+	signature := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	require.NoError(t, ValidateSignature(payload, signature, _secret))
+	require.ErrorIs(t, ValidateSignature(payload, "", _secret), ErrInvalidSignature)
+	require.ErrorIs(t, ValidateSignature(payload, "sha256=zz", _secret), ErrInvalidSignature)
+	require.ErrorIs(t, ValidateSignature([]byte("Hello"), signature, _secret), ErrInvalidSignature)
+}
+
+func TestValidatePayload(t *testing.T) {
+	t.Parallel()
+
+	payload := `{"action": "opened"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", EventIssues)
+	req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set("X-Hub-Signature-256", sign(t, payload))
+
+	got, err := ValidatePayload(req, _secret)
+	require.NoError(t, err)
+	require.JSONEq(t, payload, string(got))
+	require.Equal(t, EventIssues, EventType(req))
+	require.Equal(t, "72d3162e-cc78-11e3-81ab-4c9367dc0958", DeliveryID(req))
+
+	req = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	req.Header.Set("X-Hub-Signature-256", sign(t, "other"))
+	_, err = ValidatePayload(req, _secret)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	t.Parallel()
+
+	h, err := New(_secret)
+	require.NoError(t, err)
+
+	var gotComment *IssueCommentEvent
+	h.OnIssueComment(func(_ context.Context, e *IssueCommentEvent) error {
+		gotComment = e
+		return nil
+	})
+	var gotPush *PushEvent
+	h.OnPush(func(_ context.Context, e *PushEvent) error {
+		gotPush = e
+		return nil
+	})
+
+	payload := `{
+		"action": "created",
+		"issue": {"number": 7, "title": "Broken build", "pull_request": {"url": "https://api.github.com/repos/o/r/pulls/7"}},
+		"comment": {"id": 1, "body": "@bot please fix", "user": {"login": "alice"}},
+		"repository": {"full_name": "o/r"},
+		"sender": {"login": "alice"}
+	}`
+	rec := deliver(t, h, EventIssueComment, payload, sign(t, payload))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.NotNil(t, gotComment)
+	require.Equal(t, "created", gotComment.Action)
+	require.Equal(t, 7, gotComment.Issue.Number)
+	require.NotNil(t, gotComment.Issue.PullRequest)
+	require.Equal(t, "@bot please fix", gotComment.Comment.Body)
+	require.Equal(t, "o/r", gotComment.Repository.FullName)
+
+	payload = `{"ref": "refs/heads/main", "after": "abc", "commits": [{"id": "abc", "added": ["a.go"]}]}`
+	rec = deliver(t, h, EventPush, payload, sign(t, payload))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.NotNil(t, gotPush)
+	require.Equal(t, "refs/heads/main", gotPush.Ref)
+	require.Len(t, gotPush.Commits, 1)
+	require.Equal(t, []string{"a.go"}, gotPush.Commits[0].Added)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	t.Parallel()
+
+	h, err := New(_secret)
+	require.NoError(t, err)
+	h.OnIssues(func(_ context.Context, e *IssuesEvent) error {
+		if e.Action == "fail" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	payload := `{"action": "opened"}`
+
+	rec := deliver(t, h, EventIssues, payload, "sha256=00")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = deliver(t, h, EventIssues, `{"action":`, sign(t, `{"action":`))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = deliver(t, h, EventIssues, `{"action": "fail"}`, sign(t, `{"action": "fail"}`))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// Events without a registered func are acknowledged.
+	rec = deliver(t, h, EventPing, payload, sign(t, payload))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}